@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	rediscache "github.com/go-redis/cache/v9"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// Per-route TTLs for cached responses. /games/upcoming, /games/:id and
+// /games/search are called out explicitly in the design; the remaining list
+// routes share the general list TTL.
+const (
+	ttlGamesList = 5 * time.Minute
+	ttlUpcoming  = 1 * time.Hour
+	ttlGameByID  = 24 * time.Hour
+	ttlSearch    = 15 * time.Minute
+
+	memoryCacheSize = 1000
+)
+
+// ResponseCache caches raw JSON response bodies keyed by provider+endpoint.
+type ResponseCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	Purge(ctx context.Context) error
+
+	// GetStale returns the last known value for key even if its TTL has
+	// passed, so callers can degrade gracefully when upstream is down. The
+	// in-memory cache supports this directly; the Redis cache can only
+	// return what Redis hasn't already evicted.
+	GetStale(ctx context.Context, key string) ([]byte, bool)
+}
+
+var (
+	responseCache ResponseCache
+	fetchGroup    singleflight.Group
+)
+
+// NewResponseCache returns a Redis-backed cache when REDIS_URL is set, or an
+// in-memory LRU cache so the service still runs standalone otherwise.
+func NewResponseCache() ResponseCache {
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		if opts, err := redis.ParseURL(redisURL); err == nil {
+			return newRedisResponseCache(opts)
+		}
+		log.Println("invalid REDIS_URL, falling back to in-memory cache")
+	}
+	return newMemoryResponseCache(memoryCacheSize)
+}
+
+// redisCacheKeyPrefix namespaces every key this cache writes so Purge can
+// scope its deletion to its own keyspace -- the same Redis instance/DB may
+// also hold session data (SESSION_STORE=redis) that must survive a purge.
+const redisCacheKeyPrefix = "gamehub:cache:"
+
+type redisResponseCache struct {
+	client *redis.Client
+	cache  *rediscache.Cache
+}
+
+func newRedisResponseCache(opts *redis.Options) *redisResponseCache {
+	client := redis.NewClient(opts)
+	return &redisResponseCache{
+		client: client,
+		cache:  rediscache.New(&rediscache.Options{Redis: client}),
+	}
+}
+
+func (r *redisResponseCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	var value []byte
+	if err := r.cache.Get(ctx, redisCacheKeyPrefix+key, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (r *redisResponseCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	_ = r.cache.Set(&rediscache.Item{
+		Ctx:   ctx,
+		Key:   redisCacheKeyPrefix + key,
+		Value: value,
+		TTL:   ttl,
+	})
+}
+
+// Purge deletes only this cache's namespaced keys via SCAN, rather than
+// FlushDB, since the configured Redis may be shared with session storage or
+// other data that a cache purge shouldn't touch.
+func (r *redisResponseCache) Purge(ctx context.Context) error {
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, redisCacheKeyPrefix+"*", 100).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// GetStale is the same as Get: Redis has already evicted the key once its
+// TTL passes, so there's nothing further back to reach for.
+func (r *redisResponseCache) GetStale(ctx context.Context, key string) ([]byte, bool) {
+	return r.Get(ctx, key)
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryResponseCache is the standalone fallback used when REDIS_URL is
+// unset; it has no cross-instance sharing but keeps the module runnable.
+type memoryResponseCache struct {
+	mu    sync.Mutex
+	cache *lru.Cache[string, memoryCacheEntry]
+}
+
+func newMemoryResponseCache(size int) *memoryResponseCache {
+	cache, err := lru.New[string, memoryCacheEntry](size)
+	if err != nil {
+		log.Fatalf("failed to create response cache: %v", err)
+	}
+	return &memoryResponseCache{cache: cache}
+}
+
+func (m *memoryResponseCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.cache.Get(key)
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (m *memoryResponseCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache.Add(key, memoryCacheEntry{value: value, expiresAt: time.Now().Add(ttl)})
+}
+
+func (m *memoryResponseCache) Purge(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache.Purge()
+	return nil
+}
+
+// GetStale returns the last value stored for key regardless of whether its
+// TTL has passed -- the LRU eviction policy, not expiry, decides when it's
+// actually gone.
+func (m *memoryResponseCache) GetStale(ctx context.Context, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// cacheKey builds a stable cache key from the provider and the normalized
+// upstream endpoint (including query string).
+func cacheKey(provider, endpoint string) string {
+	if provider == "" {
+		provider = "rawg"
+	}
+	return fmt.Sprintf("%s:%s", provider, endpoint)
+}
+
+// serveGamesCached serves a cached []Game response when present, otherwise
+// fetches it -- coalescing concurrent identical misses with singleflight --
+// and populates the cache before responding.
+func serveGamesCached(c *gin.Context, ttl time.Duration, endpoint string) {
+	provider := c.Query("provider")
+	key := cacheKey(provider, endpoint)
+
+	serveCached(c, ttl, key, func(ctx context.Context) (interface{}, error) {
+		return registry.FetchGames(ctx, provider, endpoint)
+	})
+}
+
+// serveGameByIDCached mirrors serveGamesCached for the single-game lookup
+// endpoint.
+func serveGameByIDCached(c *gin.Context, ttl time.Duration, id string) {
+	provider := c.Query("provider")
+	key := cacheKey(provider, "/games/"+id)
+
+	serveCached(c, ttl, key, func(ctx context.Context) (interface{}, error) {
+		return registry.FetchGameByID(ctx, provider, id)
+	})
+}
+
+func serveCached(c *gin.Context, ttl time.Duration, key string, fetch func(ctx context.Context) (interface{}, error)) {
+	ctx := c.Request.Context()
+
+	if body, ok := responseCache.Get(ctx, key); ok {
+		setCacheHeaders(c, ttl, "HIT")
+		c.Data(http.StatusOK, "application/json", body)
+		return
+	}
+
+	result, err, _ := fetchGroup.Do(key, func() (interface{}, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		if errors.Is(err, ErrRAWGUnavailable) {
+			if stale, ok := responseCache.GetStale(ctx, key); ok {
+				setCacheHeaders(c, ttl, "STALE")
+				c.Data(http.StatusServiceUnavailable, "application/json", stale)
+				return
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	if result == nil || (reflectIsNilGame(result)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	responseCache.Set(ctx, key, body, ttl)
+	setCacheHeaders(c, ttl, "MISS")
+	c.Data(http.StatusOK, "application/json", body)
+}
+
+// reflectIsNilGame reports whether result is a nil *Game, which singleflight
+// otherwise boxes into a non-nil interface{} that a plain `== nil` check
+// would miss.
+func reflectIsNilGame(result interface{}) bool {
+	game, ok := result.(*Game)
+	return ok && game == nil
+}
+
+func setCacheHeaders(c *gin.Context, ttl time.Duration, status string) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(ttl.Seconds())))
+	c.Header("X-Cache", status)
+}
+
+// purgeCache handles POST /admin/cache/purge, gated behind a shared secret
+// so only operators can force-invalidate the cache.
+func purgeCache(c *gin.Context) {
+	secret := os.Getenv("ADMIN_CACHE_SECRET")
+	if secret == "" || c.GetHeader("X-Admin-Secret") != secret {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := responseCache.Purge(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purge cache"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "purged"})
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+const oauthStateKey = "github_oauth_state"
+
+func githubOAuthConfig() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     os.Getenv("GITHUB_CLIENT_ID"),
+		ClientSecret: os.Getenv("GITHUB_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("GITHUB_REDIRECT_URL"),
+		Scopes:       []string{"read:user"},
+		Endpoint:     github.Endpoint,
+	}
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// githubLoginHandler redirects to GitHub's authorize screen, stashing a
+// per-session state value to check on callback.
+func githubLoginHandler(c *gin.Context) {
+	state, err := randomState()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start GitHub login"})
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set(oauthStateKey, state)
+	if err := session.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start GitHub login"})
+		return
+	}
+
+	c.Redirect(http.StatusFound, githubOAuthConfig().AuthCodeURL(state))
+}
+
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// githubCallbackHandler exchanges the authorization code for a token,
+// fetches the GitHub identity, and upgrades the anonymous session to an
+// authenticated one, merging any anonymous favorites/watchlist/ratings.
+func githubCallbackHandler(c *gin.Context) {
+	session := sessions.Default(c)
+	expectedState, _ := session.Get(oauthStateKey).(string)
+	session.Delete(oauthStateKey)
+
+	if expectedState == "" || c.Query("state") != expectedState {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid OAuth state"})
+		return
+	}
+
+	config := githubOAuthConfig()
+	token, err := config.Exchange(c.Request.Context(), c.Query("code"))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to exchange GitHub code"})
+		return
+	}
+
+	client := config.Client(c.Request.Context(), token)
+	resp, err := client.Get("https://api.github.com/user")
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch GitHub profile"})
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read GitHub profile"})
+		return
+	}
+
+	var user githubUser
+	if err := json.Unmarshal(body, &user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse GitHub profile"})
+		return
+	}
+
+	authenticatedID, err := userStore.LinkGitHubAccount(currentUserID(c), strconv.FormatInt(user.ID, 10))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link GitHub account"})
+		return
+	}
+
+	session.Set(sessionUserIDKey, authenticatedID)
+	if err := session.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session"})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/")
+}
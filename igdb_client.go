@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	twitchOAuthURL = "https://id.twitch.tv/oauth2/token"
+	igdbGamesURL   = "https://api.igdb.com/v4/games"
+
+	// tokenRefreshBuffer refreshes the Twitch app token proactively instead
+	// of waiting for it to expire mid-request.
+	tokenRefreshBuffer = 5 * time.Minute
+)
+
+// IGDBProvider fetches games from IGDB, authenticating via Twitch's Client
+// Credentials OAuth flow and caching the resulting token in memory until it
+// is close to expiring.
+type IGDBProvider struct {
+	clientID     string
+	clientSecret string
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+
+	client *http.Client
+}
+
+func NewIGDBProvider() *IGDBProvider {
+	return &IGDBProvider{
+		clientID:     os.Getenv("TWITCH_CLIENT_ID"),
+		clientSecret: os.Getenv("TWITCH_CLIENT_SECRET"),
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *IGDBProvider) Name() string { return "igdb" }
+
+type twitchTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+// token returns a valid Twitch app access token, refreshing it if it's
+// missing or within tokenRefreshBuffer of expiring.
+func (p *IGDBProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Now().Before(p.expiresAt.Add(-tokenRefreshBuffer)) {
+		return p.accessToken, nil
+	}
+
+	if p.clientID == "" || p.clientSecret == "" {
+		return "", fmt.Errorf("TWITCH_CLIENT_ID/TWITCH_CLIENT_SECRET not configured")
+	}
+
+	form := url.Values{}
+	form.Set("client_id", p.clientID)
+	form.Set("client_secret", p.clientSecret)
+	form.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", twitchOAuthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting twitch token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("twitch oauth returned status %d", resp.StatusCode)
+	}
+
+	var tokenResp twitchTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("error parsing token response: %w", err)
+	}
+
+	p.accessToken = tokenResp.AccessToken
+	p.expiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	return p.accessToken, nil
+}
+
+// igdbQueryFromEndpoint translates a RAWG-style endpoint (e.g.
+// "/games?search=zelda") into an APICalypse query body for IGDB.
+func igdbQueryFromEndpoint(endpoint string) string {
+	const fields = "fields name,summary,cover.url,first_release_date,genres.name,platforms.name,rating;"
+
+	parts := strings.SplitN(endpoint, "?", 2)
+	if len(parts) != 2 {
+		return fmt.Sprintf("%s limit 20;", fields)
+	}
+
+	values, err := url.ParseQuery(parts[1])
+	if err != nil {
+		return fmt.Sprintf("%s limit 20;", fields)
+	}
+
+	if search := values.Get("search"); search != "" {
+		escaped := strings.ReplaceAll(search, `"`, `\"`)
+		return fmt.Sprintf(`%s search "%s"; limit 20;`, fields, escaped)
+	}
+
+	return fmt.Sprintf("%s limit 20;", fields)
+}
+
+type igdbGame struct {
+	ID               int            `json:"id"`
+	Name             string         `json:"name"`
+	Summary          string         `json:"summary"`
+	Cover            *igdbCover     `json:"cover"`
+	FirstReleaseDate int64          `json:"first_release_date"`
+	Genres           []igdbGenre    `json:"genres"`
+	Platforms        []igdbPlatform `json:"platforms"`
+	Rating           float64        `json:"rating"`
+}
+
+type igdbCover struct {
+	URL string `json:"url"`
+}
+
+type igdbGenre struct {
+	Name string `json:"name"`
+}
+
+type igdbPlatform struct {
+	Name string `json:"name"`
+}
+
+// request sends an APICalypse body to IGDB's /games endpoint, attaching the
+// current Twitch token and client ID.
+func (p *IGDBProvider) request(ctx context.Context, body string) ([]igdbGame, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", igdbGamesURL, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating igdb request: %w", err)
+	}
+	req.Header.Set("Client-ID", p.clientID)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making igdb request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading igdb response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("igdb returned status %d", resp.StatusCode)
+	}
+
+	var games []igdbGame
+	if err := json.Unmarshal(respBody, &games); err != nil {
+		return nil, fmt.Errorf("error parsing igdb response: %w", err)
+	}
+
+	return games, nil
+}
+
+func (p *IGDBProvider) FetchGames(ctx context.Context, endpoint string) ([]Game, error) {
+	igdbGames, err := p.request(ctx, igdbQueryFromEndpoint(endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]Game, 0, len(igdbGames))
+	for _, g := range igdbGames {
+		games = append(games, igdbGameToGame(g))
+	}
+	return games, nil
+}
+
+// validateIGDBID rejects anything but a plain positive integer, since id is
+// interpolated directly into an APICalypse query body.
+func validateIGDBID(id string) error {
+	if id == "" {
+		return fmt.Errorf("id must not be empty")
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+	if n < 1 {
+		return fmt.Errorf("id must be a positive integer")
+	}
+	return nil
+}
+
+func (p *IGDBProvider) FetchGameByID(ctx context.Context, id string) (*Game, error) {
+	if err := validateIGDBID(id); err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	body := fmt.Sprintf("fields name,summary,cover.url,first_release_date,genres.name,platforms.name,rating; where id = %s; limit 1;", id)
+	igdbGames, err := p.request(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(igdbGames) == 0 {
+		return nil, nil
+	}
+	game := igdbGameToGame(igdbGames[0])
+	return &game, nil
+}
+
+func igdbGameToGame(g igdbGame) Game {
+	var cover string
+	if g.Cover != nil {
+		cover = "https:" + strings.TrimPrefix(g.Cover.URL, "https:")
+	}
+
+	var genres []string
+	for _, genre := range g.Genres {
+		genres = append(genres, genre.Name)
+	}
+
+	var platforms []string
+	for _, platform := range g.Platforms {
+		platforms = append(platforms, platform.Name)
+	}
+
+	var released string
+	if g.FirstReleaseDate > 0 {
+		released = time.Unix(g.FirstReleaseDate, 0).UTC().Format("2006-01-02")
+	}
+
+	return Game{
+		ID:              strconv.Itoa(g.ID),
+		Title:           g.Name,
+		Description:     g.Summary,
+		BackgroundImage: cover,
+		Genres:          genres,
+		Rating:          g.Rating,
+		ReleaseDate:     released,
+		Platforms:       platforms,
+	}
+}
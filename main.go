@@ -7,12 +7,12 @@ import (
 	"os"
 	"os/signal"
 	"strings"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Store struct {
@@ -50,6 +50,7 @@ func initializeServer() *gin.Engine {
 	r.Use(limitBodySize(10 << 20)) // 10MB
 	r.Use(rateLimiter())
 	r.Use(validateInput())
+	r.Use(sessionMiddleware())
 
 	setupRoutes(r)
 	return r
@@ -62,7 +63,9 @@ func securityHeaders() gin.HandlerFunc {
 		c.Header("X-Frame-Options", "DENY")
 		c.Header("X-XSS-Protection", "1; mode=block")
 		c.Header("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
-		c.Header("Content-Security-Policy", "default-src 'self'")
+		// form-action is relaxed to allow the GitHub OAuth login redirect;
+		// everything else stays locked down to 'self'.
+		c.Header("Content-Security-Policy", "default-src 'self'; form-action 'self' https://github.com")
 		c.Next()
 	}
 }
@@ -75,32 +78,6 @@ func limitBodySize(maxBytes int64) gin.HandlerFunc {
 	}
 }
 
-// rateLimiter implementa limitação de taxa de requisições
-func rateLimiter() gin.HandlerFunc {
-	limiter := make(map[string]int64)
-	var mu sync.Mutex
-	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		mu.Lock()
-		now := time.Now().Unix()
-		// Limpa entradas antigas (mais de 1 minuto)
-		for key, timestamp := range limiter {
-			if now-timestamp > 60 {
-				delete(limiter, key)
-			}
-		}
-		// Verifica se o IP já atingiu o limite
-		if timestamp, exists := limiter[ip]; exists && now-timestamp < 1 {
-			mu.Unlock()
-			c.AbortWithStatusJSON(429, gin.H{"error": "Too many requests"})
-			return
-		}
-		limiter[ip] = now
-		mu.Unlock()
-		c.Next()
-	}
-}
-
 // validateInput valida e sanitiza as entradas
 func validateInput() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -110,10 +87,11 @@ func validateInput() gin.HandlerFunc {
 			return
 		}
 
-		// Validar Content-Type para POSTs
-		if c.Request.Method == "POST" {
+		// Validar Content-Type para POSTs com corpo (rotas sem payload, como
+		// /me/favorites/:id e /admin/cache/purge, não enviam corpo e ficam de fora)
+		if c.Request.Method == "POST" && c.Request.ContentLength > 0 {
 			contentType := c.GetHeader("Content-Type")
-			if contentType != "application/json" {
+			if !strings.HasPrefix(contentType, "application/json") {
 				c.AbortWithStatusJSON(415, gin.H{"error": "Unsupported Media Type"})
 				return
 			}
@@ -132,20 +110,61 @@ func validateInput() gin.HandlerFunc {
 }
 
 func setupRoutes(r *gin.Engine) {
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	r.GET("/games", getGames)
 	r.GET("/games/latest", getLatestGames)
 	r.GET("/games/popular", getPopularGames)
 	r.GET("/games/metacritic", getMetacriticGames)
 	r.GET("/games/upcoming", getUpcomingGames)
-	r.GET("/games/search", searchGames)
+	r.GET("/games/search", searchRateLimiter(), searchGames)
 	r.GET("/games/:id", getGameByID)
+	r.POST("/admin/cache/purge", purgeCache)
+
+	r.GET("/sitemap.xml", sitemapHandler)
+	r.GET("/sitemap_index.xml", sitemapIndexHandler)
+	r.GET("/sitemap-:page", sitemapPageHandler)
+
+	auth := r.Group("/auth/github")
+	auth.Use(ensureAnonymousSession())
+	auth.GET("/login", githubLoginHandler)
+	auth.GET("/callback", githubCallbackHandler)
+
+	me := r.Group("/me")
+	me.Use(ensureAnonymousSession())
+	me.Use(csrfMiddleware())
+	me.GET("/csrf-token", csrfTokenHandler)
+	me.GET("/favorites", listFavoritesHandler)
+	me.POST("/favorites/:id", addFavoriteHandler)
+	me.DELETE("/favorites/:id", removeFavoriteHandler)
+	me.GET("/watchlist", listWatchlistHandler)
+	me.POST("/watchlist/:id", addWatchlistHandler)
+	me.DELETE("/watchlist/:id", removeWatchlistHandler)
+	me.POST("/ratings/:id", setRatingHandler)
 }
 
+var registry *ProviderRegistry
+
 func main() {
 	if err := loadEnv(); err != nil {
 		log.Fatal(err)
 	}
 
+	registry = NewProviderRegistry()
+	responseCache = NewResponseCache()
+
+	dsn := os.Getenv("SQLITE_DSN")
+	if dsn == "" {
+		dsn = "gamehub.db"
+	}
+	var err error
+	userStore, err = NewUserStore(dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	startSitemapRefresher(context.Background())
+
 	r := initializeServer()
 	startServer(r)
 }
@@ -198,28 +217,23 @@ func getPort() string {
 }
 
 func getGames(c *gin.Context) {
-	games, err := fetchGames("/games")
-	handleResponse(c, games, err)
+	serveGamesCached(c, ttlGamesList, "/games")
 }
 
 func getLatestGames(c *gin.Context) {
-	games, err := fetchGames("/games?ordering=-released")
-	handleResponse(c, games, err)
+	serveGamesCached(c, ttlGamesList, "/games?ordering=-released")
 }
 
 func getPopularGames(c *gin.Context) {
-	games, err := fetchGames("/games?ordering=-rating")
-	handleResponse(c, games, err)
+	serveGamesCached(c, ttlGamesList, "/games?ordering=-rating")
 }
 
 func getMetacriticGames(c *gin.Context) {
-	games, err := fetchGames("/games?ordering=-metacritic")
-	handleResponse(c, games, err)
+	serveGamesCached(c, ttlGamesList, "/games?ordering=-metacritic")
 }
 
 func getUpcomingGames(c *gin.Context) {
-	games, err := fetchGames("/games?dates=2024-03-26,2025-03-26&ordering=released")
-	handleResponse(c, games, err)
+	serveGamesCached(c, ttlUpcoming, "/games?dates=2024-03-26,2025-03-26&ordering=released")
 }
 
 func searchGames(c *gin.Context) {
@@ -229,31 +243,10 @@ func searchGames(c *gin.Context) {
 		return
 	}
 
-	games, err := fetchGames("/games?search=" + query)
-	handleResponse(c, games, err)
+	serveGamesCached(c, ttlSearch, "/games?search="+query)
 }
 
 func getGameByID(c *gin.Context) {
 	id := c.Param("id")
-	game, err := fetchGameByID(id)
-
-	if err != nil {
-		c.JSON(500, gin.H{"error": "Failed to fetch game"})
-		return
-	}
-
-	if game == nil {
-		c.JSON(404, gin.H{"error": "Game not found"})
-		return
-	}
-
-	c.JSON(200, game)
-}
-
-func handleResponse(c *gin.Context, data interface{}, err error) {
-	if err != nil {
-		c.JSON(500, gin.H{"error": "Internal server error"})
-		return
-	}
-	c.JSON(200, data)
+	serveGameByIDCached(c, ttlGameByID, id)
 }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var userStore UserStore
+
+type ratingRequest struct {
+	Score int `json:"score" binding:"required,min=1,max=10"`
+}
+
+func listFavoritesHandler(c *gin.Context) {
+	gameIDs, err := userStore.ListFavorites(currentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load favorites"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"favorites": gameIDs})
+}
+
+func addFavoriteHandler(c *gin.Context) {
+	if err := userStore.AddFavorite(currentUserID(c), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add favorite"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func removeFavoriteHandler(c *gin.Context) {
+	if err := userStore.RemoveFavorite(currentUserID(c), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove favorite"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func listWatchlistHandler(c *gin.Context) {
+	gameIDs, err := userStore.ListWatchlist(currentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load watchlist"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"watchlist": gameIDs})
+}
+
+func addWatchlistHandler(c *gin.Context) {
+	if err := userStore.AddWatchlist(currentUserID(c), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add to watchlist"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func removeWatchlistHandler(c *gin.Context) {
+	if err := userStore.RemoveWatchlist(currentUserID(c), c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove from watchlist"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func setRatingHandler(c *gin.Context) {
+	var req ratingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "score must be an integer between 1 and 10"})
+		return
+	}
+
+	if err := userStore.SetRating(currentUserID(c), c.Param("id"), req.Score); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save rating"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GameProvider is implemented by each upstream game data source so handlers
+// can fetch from RAWG, IGDB, or a merge of both without caring which.
+type GameProvider interface {
+	Name() string
+	FetchGames(ctx context.Context, endpoint string) ([]Game, error)
+	FetchGameByID(ctx context.Context, id string) (*Game, error)
+}
+
+// RAWGProvider adapts the existing RAWG client functions to GameProvider.
+type RAWGProvider struct{}
+
+func (RAWGProvider) Name() string { return "rawg" }
+
+func (RAWGProvider) FetchGames(ctx context.Context, endpoint string) ([]Game, error) {
+	return fetchGames(ctx, endpoint)
+}
+
+func (RAWGProvider) FetchGameByID(ctx context.Context, id string) (*Game, error) {
+	return fetchGameByID(ctx, id)
+}
+
+// ProviderRegistry resolves the `?provider=` query param to a GameProvider,
+// and knows how to merge results when the caller asks for "merged".
+type ProviderRegistry struct {
+	providers map[string]GameProvider
+}
+
+func NewProviderRegistry() *ProviderRegistry {
+	return &ProviderRegistry{
+		providers: map[string]GameProvider{
+			"rawg": RAWGProvider{},
+			"igdb": NewIGDBProvider(),
+		},
+	}
+}
+
+// FetchGames resolves provider to "rawg", "igdb" or "merged". Anything else
+// (including an empty value) falls back to RAWG, the original source.
+func (r *ProviderRegistry) FetchGames(ctx context.Context, provider, endpoint string) ([]Game, error) {
+	switch provider {
+	case "igdb":
+		return r.providers["igdb"].FetchGames(ctx, endpoint)
+	case "merged":
+		rawgGames, err := r.providers["rawg"].FetchGames(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		igdbGames, err := r.providers["igdb"].FetchGames(ctx, endpoint)
+		if err != nil {
+			// IGDB is an enrichment source here, so don't fail the whole
+			// request just because Twitch/IGDB is unavailable.
+			return rawgGames, nil
+		}
+		return mergeGames(rawgGames, igdbGames), nil
+	default:
+		return r.providers["rawg"].FetchGames(ctx, endpoint)
+	}
+}
+
+// FetchGameByID mirrors FetchGames for the single-game lookup endpoint.
+func (r *ProviderRegistry) FetchGameByID(ctx context.Context, provider, id string) (*Game, error) {
+	switch provider {
+	case "igdb":
+		return r.providers["igdb"].FetchGameByID(ctx, id)
+	case "merged":
+		rawgGame, err := r.providers["rawg"].FetchGameByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if rawgGame == nil {
+			return r.providers["igdb"].FetchGameByID(ctx, id)
+		}
+		igdbGames, err := r.providers["igdb"].FetchGames(ctx, "/games?search="+strings.ReplaceAll(rawgGame.Title, " ", "+"))
+		if err != nil || len(igdbGames) == 0 {
+			return rawgGame, nil
+		}
+		merged := mergeGames([]Game{*rawgGame}, igdbGames)
+		return &merged[0], nil
+	default:
+		return r.providers["rawg"].FetchGameByID(ctx, id)
+	}
+}
+
+// mergeGames reconciles RAWG and secondary (IGDB) results by matching title
+// and release year, preferring non-empty fields from either source (IGDB
+// often fills in a cover or summary that RAWG is missing, and vice versa).
+func mergeGames(primary, secondary []Game) []Game {
+	index := make(map[string]int, len(primary))
+	for i, g := range primary {
+		index[mergeKey(g)] = i
+	}
+
+	merged := make([]Game, len(primary))
+	copy(merged, primary)
+
+	for _, g := range secondary {
+		key := mergeKey(g)
+		if i, ok := index[key]; ok {
+			merged[i] = fillGaps(merged[i], g)
+			continue
+		}
+		merged = append(merged, g)
+	}
+
+	return merged
+}
+
+// mergeKey identifies a game by lowercased title + release year, since RAWG
+// and IGDB rarely agree on IDs for the same title.
+func mergeKey(g Game) string {
+	year := g.ReleaseDate
+	if len(year) >= 4 {
+		year = year[:4]
+	}
+	return fmt.Sprintf("%s|%s", strings.ToLower(strings.TrimSpace(g.Title)), year)
+}
+
+// fillGaps returns base with any empty field replaced by the corresponding
+// field from fill.
+func fillGaps(base, fill Game) Game {
+	if base.Description == "" {
+		base.Description = fill.Description
+	}
+	if base.BackgroundImage == "" {
+		base.BackgroundImage = fill.BackgroundImage
+	}
+	if len(base.Genres) == 0 {
+		base.Genres = fill.Genres
+	}
+	if len(base.Platforms) == 0 {
+		base.Platforms = fill.Platforms
+	}
+	if base.Rating == 0 {
+		base.Rating = fill.Rating
+	}
+	if base.ReleaseDate == "" {
+		base.ReleaseDate = fill.ReleaseDate
+	}
+	return base
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRateLimitRPS   = 5
+	defaultRateLimitBurst = 10
+
+	defaultSearchRateLimitRPS   = 1
+	defaultSearchRateLimitBurst = 3
+
+	// limiterCacheSize bounds how many distinct client IPs we track at
+	// once; the least recently used entries are evicted beyond this.
+	limiterCacheSize = 10000
+)
+
+var rateLimitRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gamehub_rate_limit_requests_total",
+		Help: "Requests processed by the rate limiter, labeled by route and outcome (allowed/blocked).",
+	},
+	[]string{"route", "outcome"},
+)
+
+// ipLimiter hands out a token-bucket rate.Limiter per client IP, evicting the
+// least recently used entries once limiterCacheSize is exceeded.
+type ipLimiter struct {
+	mu       sync.Mutex
+	limiters *lru.Cache[string, *rate.Limiter]
+	rps      rate.Limit
+	burst    int
+}
+
+func newBoundedLimiterCache(size int) (*lru.Cache[string, *rate.Limiter], error) {
+	return lru.New[string, *rate.Limiter](size)
+}
+
+func newIPLimiter(rps float64, burst int) *ipLimiter {
+	cache, err := newBoundedLimiterCache(limiterCacheSize)
+	if err != nil {
+		log.Fatalf("failed to create rate limiter cache: %v", err)
+	}
+	return &ipLimiter{
+		limiters: cache,
+		rps:      rate.Limit(rps),
+		burst:    burst,
+	}
+}
+
+func (l *ipLimiter) get(ip string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limiter, ok := l.limiters.Get(ip); ok {
+		return limiter
+	}
+
+	limiter := rate.NewLimiter(l.rps, l.burst)
+	l.limiters.Add(ip, limiter)
+	return limiter
+}
+
+// rateLimitMiddleware enforces a token-bucket rate limit per client IP,
+// setting Retry-After and returning 429 once a bucket is exhausted.
+func rateLimitMiddleware(route string, limiter *ipLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientLimiter := limiter.get(c.ClientIP())
+
+		reservation := clientLimiter.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			rateLimitRequests.WithLabelValues(route, "blocked").Inc()
+			c.Header("Retry-After", strconv.Itoa(int(delay.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+
+		rateLimitRequests.WithLabelValues(route, "allowed").Inc()
+		c.Next()
+	}
+}
+
+func envFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+func envInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// rateLimiter is the general-purpose per-IP limiter applied to every route.
+func rateLimiter() gin.HandlerFunc {
+	rps := envFloat("RATE_LIMIT_RPS", defaultRateLimitRPS)
+	burst := envInt("RATE_LIMIT_BURST", defaultRateLimitBurst)
+	return rateLimitMiddleware("default", newIPLimiter(rps, burst))
+}
+
+// searchRateLimiter is a stricter limiter layered on top of rateLimiter for
+// /games/search, which is more expensive upstream than the other routes.
+func searchRateLimiter() gin.HandlerFunc {
+	rps := envFloat("RATE_LIMIT_SEARCH_RPS", defaultSearchRateLimitRPS)
+	burst := envInt("RATE_LIMIT_SEARCH_BURST", defaultSearchRateLimitBurst)
+	return rateLimitMiddleware("search", newIPLimiter(rps, burst))
+}
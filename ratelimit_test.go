@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newLimitedRouter(limiter *ipLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", rateLimitMiddleware("test", limiter), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func doRequest(r *gin.Engine, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = ip + ":1234"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddlewareAllowsBurst(t *testing.T) {
+	limiter := newIPLimiter(1, 3)
+	r := newLimitedRouter(limiter)
+
+	for i := 0; i < 3; i++ {
+		if w := doRequest(r, "1.2.3.4"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200 within burst, got %d", i, w.Code)
+		}
+	}
+
+	w := doRequest(r, "1.2.3.4")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once burst is exhausted, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatal("expected Retry-After header on 429 response")
+	}
+}
+
+func TestRateLimitMiddlewareIsPerIP(t *testing.T) {
+	limiter := newIPLimiter(1, 1)
+	r := newLimitedRouter(limiter)
+
+	if w := doRequest(r, "10.0.0.1"); w.Code != http.StatusOK {
+		t.Fatalf("expected first request from 10.0.0.1 to succeed, got %d", w.Code)
+	}
+	if w := doRequest(r, "10.0.0.1"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected second request from 10.0.0.1 to be limited, got %d", w.Code)
+	}
+	if w := doRequest(r, "10.0.0.2"); w.Code != http.StatusOK {
+		t.Fatalf("expected first request from a different IP to succeed, got %d", w.Code)
+	}
+}
+
+func TestIPLimiterEvictsLeastRecentlyUsed(t *testing.T) {
+	limiter := &ipLimiter{
+		rps:   1,
+		burst: 1,
+	}
+	cache, err := newBoundedLimiterCache(2)
+	if err != nil {
+		t.Fatalf("unexpected error creating cache: %v", err)
+	}
+	limiter.limiters = cache
+
+	first := limiter.get("1.1.1.1")
+	limiter.get("2.2.2.2")
+	limiter.get("3.3.3.3") // evicts 1.1.1.1, the least recently used entry
+
+	if limiter.limiters.Contains("1.1.1.1") {
+		t.Fatal("expected 1.1.1.1 to be evicted once the cache exceeded its size")
+	}
+
+	refreshed := limiter.get("1.1.1.1")
+	if refreshed == first {
+		t.Fatal("expected a fresh limiter after eviction, got the same instance")
+	}
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/sony/gobreaker"
 )
 
 const (
@@ -122,32 +127,127 @@ func validateURL(endpoint string) error {
 	return nil
 }
 
-func makeRequest(urlStr string) (*http.Response, error) {
-	_, err := url.Parse(urlStr)
-	if err != nil {
+const maxRAWGRetries = 3
+
+// ErrRAWGUnavailable is returned when the circuit breaker around RAWG is
+// open; callers can fall back to a stale cached response when they see it.
+var ErrRAWGUnavailable = errors.New("rawg is temporarily unavailable")
+
+// rawgHTTPClient is shared across requests (instead of one per call) so
+// keep-alives and HTTP/2 connection reuse actually happen.
+var rawgHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 20,
+		IdleConnTimeout:     90 * time.Second,
+		ForceAttemptHTTP2:   true,
+	},
+}
+
+// rawgBreaker trips after 5 consecutive failures and stays open for 30s,
+// shedding load onto RAWG while it's flaky instead of piling up requests.
+var rawgBreaker = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+	Name:    "rawg",
+	Timeout: 30 * time.Second,
+	ReadyToTrip: func(counts gobreaker.Counts) bool {
+		return counts.ConsecutiveFailures >= 5
+	},
+})
+
+// makeRequest fetches urlStr through the circuit breaker, retrying
+// transient failures with backoff and honoring ctx cancellation throughout.
+func makeRequest(ctx context.Context, urlStr string) (*http.Response, error) {
+	if _, err := url.Parse(urlStr); err != nil {
 		return nil, fmt.Errorf("invalid URL: %w", err)
 	}
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	result, err := rawgBreaker.Execute(func() (interface{}, error) {
+		return doRequestWithRetry(ctx, urlStr)
+	})
+	if err != nil {
+		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+			return nil, ErrRAWGUnavailable
+		}
+		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", urlStr, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+	return result.(*http.Response), nil
+}
+
+// doRequestWithRetry makes at most maxRAWGRetries attempts in total,
+// retrying failed or throttled requests and honoring a Retry-After header on
+// 429/503, falling back to exponential backoff otherwise.
+func doRequestWithRetry(ctx context.Context, urlStr string) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < maxRAWGRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("User-Agent", "GameHub/1.0")
+
+		resp, err := rawgHTTPClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = fmt.Errorf("error making request: %w", err)
+			if attempt == maxRAWGRetries-1 || !sleepBackoff(ctx, attempt, 0) {
+				return nil, lastErr
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("rawg returned status %d", resp.StatusCode)
+			if attempt == maxRAWGRetries-1 || !sleepBackoff(ctx, attempt, retryAfter) {
+				return nil, lastErr
+			}
+			continue
+		}
+
+		return resp, nil
 	}
 
-	req.Header.Set("User-Agent", "GameHub/1.0")
+	return nil, lastErr
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+// parseRetryAfter understands both the delay-seconds and HTTP-date forms of
+// the Retry-After header, defaulting to 0 (use backoff instead) otherwise.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// sleepBackoff waits retryAfter (or an exponential backoff if unset) before
+// the next attempt, returning false if ctx is canceled first.
+func sleepBackoff(ctx context.Context, attempt int, retryAfter time.Duration) bool {
+	delay := retryAfter
+	if delay <= 0 {
+		delay = (200 * time.Millisecond) << attempt
 	}
 
-	return resp, nil
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+		return true
+	}
 }
 
-func fetchGames(endpoint string) ([]Game, error) {
+func fetchGames(ctx context.Context, endpoint string) ([]Game, error) {
 	if err := validateURL(endpoint); err != nil {
 		return nil, fmt.Errorf("invalid endpoint: %w", err)
 	}
@@ -164,7 +264,7 @@ func fetchGames(endpoint string) ([]Game, error) {
 		urlStr = fmt.Sprintf("%s?key=%s&page_size=20", urlStr, apiKey)
 	}
 
-	resp, err := makeRequest(urlStr)
+	resp, err := makeRequest(ctx, urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +321,7 @@ func getPlatforms(platforms []Platform) []string {
 	return platformNames
 }
 
-func fetchGameByID(id string) (*Game, error) {
+func fetchGameByID(ctx context.Context, id string) (*Game, error) {
 	if id == "" {
 		return nil, fmt.Errorf("game ID cannot be empty")
 	}
@@ -238,7 +338,7 @@ func fetchGameByID(id string) (*Game, error) {
 
 	urlStr := fmt.Sprintf("%s%s?key=%s", baseAPIURL, endpoint, apiKey)
 
-	resp, err := makeRequest(urlStr)
+	resp, err := makeRequest(ctx, urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -273,4 +373,4 @@ func fetchGameByID(id string) (*Game, error) {
 	}
 
 	return game, nil
-} 
\ No newline at end of file
+}
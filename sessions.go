@@ -0,0 +1,78 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-contrib/sessions/redis"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	sessionName      = "gamehub_session"
+	sessionUserIDKey = "user_id"
+)
+
+// newSessionStore picks a cookie-backed store by default, or a Redis-backed
+// one when SESSION_STORE=redis (and REDIS_URL is set) so sessions survive
+// across instances.
+func newSessionStore() sessions.Store {
+	secret := os.Getenv("SESSION_SECRET")
+	if secret == "" {
+		secret = "dev-insecure-secret"
+	}
+
+	if os.Getenv("SESSION_STORE") == "redis" {
+		if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+			addr, password := redisAddrAndPassword(redisURL)
+			if store, err := redis.NewStore(10, "tcp", addr, password, []byte(secret)); err == nil {
+				return store
+			} else {
+				log.Printf("falling back to cookie session store: %v", err)
+			}
+		}
+	}
+
+	return cookie.NewStore([]byte(secret))
+}
+
+func redisAddrAndPassword(redisURL string) (addr, password string) {
+	opts, err := goredis.ParseURL(redisURL)
+	if err != nil {
+		return "localhost:6379", ""
+	}
+	return opts.Addr, opts.Password
+}
+
+// sessionMiddleware wires gin-contrib/sessions into the request pipeline.
+func sessionMiddleware() gin.HandlerFunc {
+	return sessions.Sessions(sessionName, newSessionStore())
+}
+
+// ensureAnonymousSession assigns every visitor an anonymous UUID on first
+// contact, so /me/* routes always have a user to key data off of even
+// before GitHub login.
+func ensureAnonymousSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+		if session.Get(sessionUserIDKey) == nil {
+			session.Set(sessionUserIDKey, uuid.NewString())
+			if err := session.Save(); err != nil {
+				c.AbortWithStatusJSON(500, gin.H{"error": "Failed to create session"})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// currentUserID returns the anonymous or GitHub-linked ID for this session.
+func currentUserID(c *gin.Context) string {
+	session := sessions.Default(c)
+	id, _ := session.Get(sessionUserIDKey).(string)
+	return id
+}
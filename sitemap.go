@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	sitemapXMLNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+	// sitemapTTL is how long the crawled game entry list stays in the
+	// response cache, since the RAWG pages behind it are expensive to
+	// re-fetch, and the max-age sent to clients. The rendered XML itself is
+	// not cached; it is rebuilt from the cached entries on every request.
+	sitemapTTL = 6 * time.Hour
+
+	// sitemapRefreshInterval drives the background crawl that repopulates the
+	// sitemap entry cache ahead of sitemapTTL expiring. collectSitemapEntries
+	// can take minutes at maxSitemapPagesPerSource, far longer than the
+	// server's request WriteTimeout, so it never runs inline on a request --
+	// only on this ticker.
+	sitemapRefreshInterval = sitemapTTL - 30*time.Minute
+
+	// sitemapURLLimit and sitemapBytesLimit mirror the sitemap protocol's
+	// per-file caps; once exceeded we split into sitemap-1.xml, -2.xml, etc.
+	sitemapURLLimit   = 50000
+	sitemapBytesLimit = 50 * 1024 * 1024
+
+	// estimatedSitemapURLBytes is a conservative per-<url> size (loc +
+	// lastmod + changefreq + tags). In practice sitemapURLLimit is the
+	// binding constraint for game-detail URLs of this length; the byte math
+	// is a safety net in case loc/lastmod ever grow much longer than
+	// expected, not something normal traffic is expected to hit.
+	estimatedSitemapURLBytes = 200
+
+	// maxSitemapPagesPerSource bounds RAWG pagination per source list; at the
+	// shared fetchGames page_size of 20 and two sources, 1250 pages lets the
+	// background refresher actually reach sitemapURLLimit instead of
+	// stopping far short of it. This only runs off the request path (see
+	// startSitemapRefresher), so it isn't bound by WriteTimeout.
+	maxSitemapPagesPerSource = 1250
+
+	sitemapEntriesCacheKey = "sitemap:entries"
+)
+
+type sitemapEntry struct {
+	Game       Game   `json:"game"`
+	ChangeFreq string `json:"change_freq"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name            `xml:"sitemapindex"`
+	Xmlns    string              `xml:"xmlns,attr"`
+	Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+}
+
+type sitemapIndexEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// collectSitemapEntries walks the popular and upcoming game lists across
+// RAWG pages, deduplicating by game ID and stopping once sitemapURLLimit is
+// reached.
+func collectSitemapEntries(ctx context.Context) ([]sitemapEntry, error) {
+	sources := []struct {
+		endpoint   string
+		changeFreq string
+	}{
+		{"/games?ordering=-rating", "weekly"},
+		{"/games?dates=2024-03-26,2025-03-26&ordering=released", "daily"},
+	}
+
+	seen := make(map[string]bool)
+	var entries []sitemapEntry
+
+	for _, source := range sources {
+		for page := 1; page <= maxSitemapPagesPerSource; page++ {
+			if len(entries) >= sitemapURLLimit {
+				return entries, nil
+			}
+
+			endpoint := fmt.Sprintf("%s&page=%d", source.endpoint, page)
+			pageGames, err := registry.FetchGames(ctx, "", endpoint)
+			if err != nil {
+				return nil, err
+			}
+			if len(pageGames) == 0 {
+				break
+			}
+
+			for _, g := range pageGames {
+				if seen[g.ID] {
+					continue
+				}
+				seen[g.ID] = true
+				entries = append(entries, sitemapEntry{Game: g, ChangeFreq: source.changeFreq})
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// getSitemapEntries returns the entry list populated by the background
+// sitemap refresher, and ok=false if that hasn't completed yet -- handlers
+// never fall back to collectSitemapEntries inline, since it can run far
+// longer than the server's request WriteTimeout.
+func getSitemapEntries(ctx context.Context) (entries []sitemapEntry, ok bool) {
+	cached, ok := responseCache.Get(ctx, sitemapEntriesCacheKey)
+	if !ok {
+		return nil, false
+	}
+	if err := json.Unmarshal(cached, &entries); err != nil {
+		return nil, false
+	}
+	return entries, true
+}
+
+// startSitemapRefresher rebuilds the sitemap entry cache in the background on
+// sitemapRefreshInterval, starting with an immediate crawl so the cache is
+// warm soon after boot without delaying server startup.
+func startSitemapRefresher(ctx context.Context) {
+	go func() {
+		refreshSitemapEntries(ctx)
+
+		ticker := time.NewTicker(sitemapRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refreshSitemapEntries(ctx)
+			}
+		}
+	}()
+}
+
+// refreshSitemapEntries crawls RAWG via collectSitemapEntries and stores the
+// result under sitemapEntriesCacheKey, logging rather than failing a request
+// since nothing is waiting on it synchronously.
+func refreshSitemapEntries(ctx context.Context) {
+	entries, err := collectSitemapEntries(ctx)
+	if err != nil {
+		log.Printf("sitemap refresh failed: %v", err)
+		return
+	}
+
+	body, err := json.Marshal(entries)
+	if err != nil {
+		log.Printf("sitemap refresh: failed to marshal entries: %v", err)
+		return
+	}
+	responseCache.Set(ctx, sitemapEntriesCacheKey, body, sitemapTTL)
+}
+
+// chunkSitemapEntries splits entries into groups no larger than
+// sitemapURLLimit entries or sitemapBytesLimit estimated bytes, whichever is
+// smaller, one per eventual sitemap file.
+func chunkSitemapEntries(entries []sitemapEntry) [][]sitemapEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	maxPerChunk := sitemapURLLimit
+	if byByteCap := sitemapBytesLimit / estimatedSitemapURLBytes; byByteCap < maxPerChunk {
+		maxPerChunk = byByteCap
+	}
+
+	var chunks [][]sitemapEntry
+	for i := 0; i < len(entries); i += maxPerChunk {
+		end := i + maxPerChunk
+		if end > len(entries) {
+			end = len(entries)
+		}
+		chunks = append(chunks, entries[i:end])
+	}
+	return chunks
+}
+
+func baseURL(c *gin.Context) string {
+	if base := os.Getenv("BASE_URL"); base != "" {
+		return strings.TrimSuffix(base, "/")
+	}
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, c.Request.Host)
+}
+
+// normalizeLastMod converts RAWG's "updated" timestamp to RFC3339 for
+// <lastmod>, or "" if it can't be parsed.
+func normalizeLastMod(updated string) string {
+	layouts := []string{"2006-01-02 15:04:05", time.RFC3339}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, updated); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// renderURLSet encodes a <urlset> document for one chunk of entries. The
+// whole document is built in memory and returned as one byte slice; it is
+// not streamed to the client.
+func renderURLSet(base string, chunk []sitemapEntry) ([]byte, error) {
+	urlset := sitemapURLSet{Xmlns: sitemapXMLNS}
+	for _, e := range chunk {
+		urlset.URLs = append(urlset.URLs, sitemapURL{
+			Loc:        fmt.Sprintf("%s/games/%s", base, e.Game.ID),
+			LastMod:    normalizeLastMod(e.Game.Updated),
+			ChangeFreq: e.ChangeFreq,
+		})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(urlset); err != nil {
+		return nil, fmt.Errorf("error encoding sitemap: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSitemapIndex encodes a <sitemapindex> pointing at sitemap-1.xml..N,
+// built in memory and returned as one byte slice like renderURLSet.
+func renderSitemapIndex(base string, numChunks int) ([]byte, error) {
+	idx := sitemapIndex{Xmlns: sitemapXMLNS}
+	for i := 1; i <= numChunks; i++ {
+		idx.Sitemaps = append(idx.Sitemaps, sitemapIndexEntry{
+			Loc: fmt.Sprintf("%s/sitemap-%d.xml", base, i),
+		})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(idx); err != nil {
+		return nil, fmt.Errorf("error encoding sitemap index: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func setSitemapHeaders(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(sitemapTTL.Seconds())))
+}
+
+// sitemapHandler serves GET /sitemap.xml. If the game list fits in a single
+// file it's served directly; otherwise clients are redirected to the index.
+func sitemapHandler(c *gin.Context) {
+	entries, ok := getSitemapEntries(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Sitemap is still being generated"})
+		return
+	}
+
+	chunks := chunkSitemapEntries(entries)
+	if len(chunks) > 1 {
+		c.Redirect(http.StatusMovedPermanently, "/sitemap_index.xml")
+		return
+	}
+
+	var chunk []sitemapEntry
+	if len(chunks) == 1 {
+		chunk = chunks[0]
+	}
+
+	body, err := renderURLSet(baseURL(c), chunk)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render sitemap"})
+		return
+	}
+
+	setSitemapHeaders(c)
+	c.Data(http.StatusOK, "application/xml", body)
+}
+
+// sitemapIndexHandler serves GET /sitemap_index.xml, listing one entry per
+// chunk produced by chunkSitemapEntries.
+func sitemapIndexHandler(c *gin.Context) {
+	entries, ok := getSitemapEntries(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Sitemap is still being generated"})
+		return
+	}
+
+	body, err := renderSitemapIndex(baseURL(c), len(chunkSitemapEntries(entries)))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render sitemap index"})
+		return
+	}
+
+	setSitemapHeaders(c)
+	c.Data(http.StatusOK, "application/xml", body)
+}
+
+// sitemapPageHandler serves GET /sitemap-:page.xml, one urlset per chunk.
+func sitemapPageHandler(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sitemap page not found"})
+		return
+	}
+
+	entries, ok := getSitemapEntries(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Sitemap is still being generated"})
+		return
+	}
+
+	chunks := chunkSitemapEntries(entries)
+	if page > len(chunks) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sitemap page not found"})
+		return
+	}
+
+	body, err := renderURLSet(baseURL(c), chunks[page-1])
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render sitemap"})
+		return
+	}
+
+	setSitemapHeaders(c)
+	c.Data(http.StatusOK, "application/xml", body)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserRecord links an anonymous session ID to an optional GitHub identity.
+type UserRecord struct {
+	gorm.Model
+	AnonymousID string `gorm:"column:anonymous_id;uniqueIndex"`
+	GitHubID    string `gorm:"column:github_id;uniqueIndex"`
+}
+
+// Favorite is a user's favorited game.
+type Favorite struct {
+	gorm.Model
+	UserID string `gorm:"uniqueIndex:idx_favorite_user_game"`
+	GameID string `gorm:"uniqueIndex:idx_favorite_user_game"`
+}
+
+// WatchlistItem is a game a user intends to play.
+type WatchlistItem struct {
+	gorm.Model
+	UserID string `gorm:"uniqueIndex:idx_watchlist_user_game"`
+	GameID string `gorm:"uniqueIndex:idx_watchlist_user_game"`
+}
+
+// Rating is a user's 1-10 score for a game; re-rating updates it in place.
+type Rating struct {
+	gorm.Model
+	UserID string `gorm:"uniqueIndex:idx_rating_user_game"`
+	GameID string `gorm:"uniqueIndex:idx_rating_user_game"`
+	Score  int
+}
+
+// UserStore persists per-user data. It's backed by SQLite via gorm today;
+// swapping in Postgres only requires a different gorm.Dialector in
+// NewUserStore.
+type UserStore interface {
+	AddFavorite(userID, gameID string) error
+	RemoveFavorite(userID, gameID string) error
+	ListFavorites(userID string) ([]string, error)
+
+	AddWatchlist(userID, gameID string) error
+	RemoveWatchlist(userID, gameID string) error
+	ListWatchlist(userID string) ([]string, error)
+
+	SetRating(userID, gameID string, score int) error
+
+	// MergeAnonymous reassigns an anonymous session's favorites, watchlist
+	// and ratings to an authenticated user ID, e.g. after GitHub login.
+	MergeAnonymous(anonymousID, authenticatedID string) error
+
+	// LinkGitHubAccount upgrades an anonymous session to an authenticated
+	// one identified by a GitHub user ID, merging any anonymous activity
+	// into it, and returns the durable user ID to store in the session.
+	LinkGitHubAccount(anonymousID, githubID string) (userID string, err error)
+}
+
+type gormUserStore struct {
+	db *gorm.DB
+}
+
+// NewUserStore opens (and migrates) a SQLite database at dsn.
+func NewUserStore(dsn string) (UserStore, error) {
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("error opening user store: %w", err)
+	}
+
+	if err := db.AutoMigrate(&UserRecord{}, &Favorite{}, &WatchlistItem{}, &Rating{}); err != nil {
+		return nil, fmt.Errorf("error migrating user store: %w", err)
+	}
+
+	return &gormUserStore{db: db}, nil
+}
+
+func (s *gormUserStore) AddFavorite(userID, gameID string) error {
+	fav := Favorite{UserID: userID, GameID: gameID}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&fav).Error
+}
+
+func (s *gormUserStore) RemoveFavorite(userID, gameID string) error {
+	return s.db.Where("user_id = ? AND game_id = ?", userID, gameID).Delete(&Favorite{}).Error
+}
+
+func (s *gormUserStore) ListFavorites(userID string) ([]string, error) {
+	var favorites []Favorite
+	if err := s.db.Where("user_id = ?", userID).Find(&favorites).Error; err != nil {
+		return nil, err
+	}
+	gameIDs := make([]string, len(favorites))
+	for i, f := range favorites {
+		gameIDs[i] = f.GameID
+	}
+	return gameIDs, nil
+}
+
+func (s *gormUserStore) AddWatchlist(userID, gameID string) error {
+	item := WatchlistItem{UserID: userID, GameID: gameID}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&item).Error
+}
+
+func (s *gormUserStore) RemoveWatchlist(userID, gameID string) error {
+	return s.db.Where("user_id = ? AND game_id = ?", userID, gameID).Delete(&WatchlistItem{}).Error
+}
+
+func (s *gormUserStore) ListWatchlist(userID string) ([]string, error) {
+	var items []WatchlistItem
+	if err := s.db.Where("user_id = ?", userID).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	gameIDs := make([]string, len(items))
+	for i, it := range items {
+		gameIDs[i] = it.GameID
+	}
+	return gameIDs, nil
+}
+
+func (s *gormUserStore) SetRating(userID, gameID string, score int) error {
+	if score < 1 || score > 10 {
+		return errors.New("score must be between 1 and 10")
+	}
+
+	rating := Rating{UserID: userID, GameID: gameID, Score: score}
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "game_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"score"}),
+	}).Create(&rating).Error
+}
+
+// MergeAnonymous re-creates the anonymous session's rows under
+// authenticatedID and drops the anonymous originals. Favorites and watchlist
+// entries the authenticated user already has are skipped; a rating for a game
+// the authenticated user already rated is overwritten with the anonymous
+// score. Rows are re-created rather than updated since a plain UPDATE could collide with the
+// per-user-per-game unique indexes. The primary key is zeroed before each
+// re-create so the INSERT conflicts on (user_id, game_id) as intended,
+// rather than on the anonymous row's own id.
+func (s *gormUserStore) MergeAnonymous(anonymousID, authenticatedID string) error {
+	if anonymousID == authenticatedID {
+		return nil
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var favorites []Favorite
+		if err := tx.Where("user_id = ?", anonymousID).Find(&favorites).Error; err != nil {
+			return err
+		}
+		for _, f := range favorites {
+			f.Model = gorm.Model{}
+			f.UserID = authenticatedID
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&f).Error; err != nil {
+				return err
+			}
+		}
+
+		var watchlist []WatchlistItem
+		if err := tx.Where("user_id = ?", anonymousID).Find(&watchlist).Error; err != nil {
+			return err
+		}
+		for _, w := range watchlist {
+			w.Model = gorm.Model{}
+			w.UserID = authenticatedID
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&w).Error; err != nil {
+				return err
+			}
+		}
+
+		var ratings []Rating
+		if err := tx.Where("user_id = ?", anonymousID).Find(&ratings).Error; err != nil {
+			return err
+		}
+		for _, r := range ratings {
+			r.Model = gorm.Model{}
+			r.UserID = authenticatedID
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "user_id"}, {Name: "game_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"score"}),
+			}).Create(&r).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("user_id = ?", anonymousID).Delete(&Favorite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", anonymousID).Delete(&WatchlistItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", anonymousID).Delete(&Rating{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// LinkGitHubAccount implements UserStore.
+func (s *gormUserStore) LinkGitHubAccount(anonymousID, githubID string) (string, error) {
+	authenticatedID := fmt.Sprintf("github:%s", githubID)
+
+	var record UserRecord
+	err := s.db.Where("github_id = ?", githubID).First(&record).Error
+	switch {
+	case err == nil:
+		// Already linked; fall through to merge any fresh anonymous activity.
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		record = UserRecord{AnonymousID: anonymousID, GitHubID: githubID}
+		if err := s.db.Create(&record).Error; err != nil {
+			return "", fmt.Errorf("error creating user record: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("error looking up github account: %w", err)
+	}
+
+	if err := s.MergeAnonymous(anonymousID, authenticatedID); err != nil {
+		return "", err
+	}
+
+	return authenticatedID, nil
+}